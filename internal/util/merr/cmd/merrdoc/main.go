@@ -0,0 +1,47 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command merrdoc dumps the merr error-code registry to the repo's
+// docs/error_codes.md, keeping the generated reference in sync with
+// registry.go. Run via `go generate ./internal/util/merr/...`.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+// repoRoot is derived from this file's own path rather than the working
+// directory, since go:generate runs with cwd set to the package declaring
+// the directive (internal/util/merr), not necessarily the repo root.
+func repoRoot() string {
+	_, thisFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(thisFile), "..", "..", "..", "..", "..")
+}
+
+func main() {
+	content := "<!-- Code generated by merrdoc. DO NOT EDIT. -->\n\n" +
+		"# Milvus error codes\n\n" +
+		merr.DumpMarkdown()
+
+	outPath := filepath.Join(repoRoot(), "docs", "error_codes.md")
+	if err := os.WriteFile(outPath, []byte(content), 0o644); err != nil {
+		panic(err)
+	}
+}
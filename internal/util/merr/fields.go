@@ -0,0 +1,110 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+)
+
+// fieldsError carries structured key-value fields alongside the rendered
+// "k=v" message.
+type fieldsError struct {
+	error
+	fields map[string]any
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.error
+}
+
+type fieldPair struct {
+	key   string
+	value any
+}
+
+// withFields wraps err with one or more fields, folding them into a single
+// "k1=v1, k2=v2" message.
+func withFields(err error, pairs ...fieldPair) error {
+	if err == nil {
+		return nil
+	}
+
+	parts := make([]string, len(pairs))
+	fields := make(map[string]any, len(pairs))
+	for i, p := range pairs {
+		parts[i] = fmt.Sprintf("%s=%v", p.key, p.value)
+		fields[p.key] = p.value
+	}
+
+	wrapped := errors.Wrap(err, strings.Join(parts, ", "))
+	return &fieldsError{error: wrapped, fields: fields}
+}
+
+// attachFields wraps an already-rendered error with structured fields
+// without adding any further text to the message.
+func attachFields(wrapped error, fields map[string]any) error {
+	if wrapped == nil {
+		return nil
+	}
+	return &fieldsError{error: wrapped, fields: fields}
+}
+
+// WithField attaches a single structured key-value field to err.
+func WithField(err error, k string, v any) error {
+	return withFields(err, fieldPair{key: k, value: v})
+}
+
+// Fields returns the structured fields attached to err via WithField.
+func Fields(err error) map[string]any {
+	fields := map[string]any{}
+	for err != nil {
+		if fe, ok := err.(*fieldsError); ok {
+			for k, v := range fe.fields {
+				if _, exists := fields[k]; !exists {
+					fields[k] = v
+				}
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
+
+// ZapFields renders the fields attached to err as zap.Field values, sorted by key.
+func ZapFields(err error) []zap.Field {
+	fields := Fields(err)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	zapFields := make([]zap.Field, 0, len(keys))
+	for _, k := range keys {
+		zapFields = append(zapFields, zap.Any(k, fields[k]))
+	}
+	return zapFields
+}
@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merr
+
+import (
+	"testing"
+)
+
+func TestWithFieldAndFields(t *testing.T) {
+	err := WithField(ErrCollectionNotFound, "collection", "test_collection")
+
+	fields := Fields(err)
+	if fields["collection"] != "test_collection" {
+		t.Fatalf("expected collection field, got %v", fields)
+	}
+}
+
+func TestFieldsMergesNestedWraps(t *testing.T) {
+	err := WithField(ErrCollectionNotFound, "collection", "test_collection")
+	err = WithField(err, "partition", "test_partition")
+
+	fields := Fields(err)
+	if fields["collection"] != "test_collection" {
+		t.Fatalf("expected collection field from the inner wrap, got %v", fields)
+	}
+	if fields["partition"] != "test_partition" {
+		t.Fatalf("expected partition field from the outer wrap, got %v", fields)
+	}
+}
+
+func TestFieldsOutermostWinsOnCollision(t *testing.T) {
+	err := WithField(ErrCollectionNotFound, "collection", "inner")
+	err = WithField(err, "collection", "outer")
+
+	fields := Fields(err)
+	if fields["collection"] != "outer" {
+		t.Fatalf("expected the outermost wrap's value to win, got %v", fields["collection"])
+	}
+}
+
+func TestFieldsNilError(t *testing.T) {
+	if fields := Fields(nil); len(fields) != 0 {
+		t.Fatalf("expected no fields for a nil error, got %v", fields)
+	}
+	if WithField(nil, "k", "v") != nil {
+		t.Fatal("expected WithField on a nil error to return nil")
+	}
+}
+
+func TestZapFieldsSortedByKey(t *testing.T) {
+	err := WithField(ErrCollectionNotFound, "zeta", 1)
+	err = WithField(err, "alpha", 2)
+
+	zapFields := ZapFields(err)
+	if len(zapFields) != 2 {
+		t.Fatalf("expected 2 zap fields, got %d", len(zapFields))
+	}
+	if zapFields[0].Key != "alpha" || zapFields[1].Key != "zeta" {
+		t.Fatalf("expected fields sorted by key, got %s, %s", zapFields[0].Key, zapFields[1].Key)
+	}
+}
+
+func TestZapFieldsEmpty(t *testing.T) {
+	if zapFields := ZapFields(ErrCollectionNotFound); zapFields != nil {
+		t.Fatalf("expected nil for an error with no attached fields, got %v", zapFields)
+	}
+}
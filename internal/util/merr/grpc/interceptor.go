@@ -0,0 +1,178 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpc translates merr errors to and from canonical gRPC status values.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus-proto/go-api/commonpb"
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+// errorInfoDomain identifies merr-originated errors in an errdetails.ErrorInfo.
+const errorInfoDomain = "milvus"
+
+// grpcCode resolves the canonical gRPC code for a merr error code via merr.Describe.
+func grpcCode(mCode int32) codes.Code {
+	if d, ok := merr.Describe(mCode); ok {
+		return d.GRPCCode
+	}
+	switch mCode {
+	case merr.CanceledCode:
+		return codes.Canceled
+	case merr.TimeoutCode:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unknown
+	}
+}
+
+// ToStatus returns a gRPC status for err, analogous to merr.Status.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+
+	mCode := merr.Code(err)
+	s := status.New(grpcCode(mCode), err.Error())
+
+	withDetails, detailErr := s.WithDetails(&errdetails.ErrorInfo{
+		Reason:   strconv.FormatInt(int64(mCode), 10),
+		Domain:   errorInfoDomain,
+		Metadata: stringifyFields(merr.Fields(err)),
+	})
+	if detailErr == nil {
+		s = withDetails
+	}
+
+	if merr.IsRetriable(err) {
+		if withDetails, detailErr := s.WithDetails(&errdetails.RetryInfo{}); detailErr == nil {
+			s = withDetails
+		}
+	}
+
+	return s
+}
+
+// FromStatus returns a milvus error for a status produced by ToStatus, analogous to merr.Error.
+func FromStatus(s *status.Status) error {
+	if s == nil || s.Code() == codes.OK {
+		return nil
+	}
+
+	mCode, ok := milvusCodeFromDetails(s)
+	if !ok {
+		// Status didn't originate from ToStatus (e.g. a framework-generated
+		// error, or a peer that hasn't rolled out this interceptor yet).
+		// Code stays 0, so set a non-success ErrorCode to force merr.Error's
+		// legacy-code path (which falls back to errUnexpected) instead of
+		// its Code==0/ErrorCode==Success "no error" case.
+		return merr.Error(&commonpb.Status{ErrorCode: commonpb.ErrorCode_UnexpectedError, Reason: s.Message()})
+	}
+
+	return merr.Error(&commonpb.Status{Code: mCode, Reason: s.Message()})
+}
+
+// stringifyFields renders merr.Fields as an errdetails.ErrorInfo metadata map.
+func stringifyFields(fields map[string]any) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(fields))
+	for k, v := range fields {
+		metadata[k] = fmt.Sprintf("%v", v)
+	}
+	return metadata
+}
+
+func milvusCodeFromDetails(s *status.Status) (int32, bool) {
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != errorInfoDomain {
+			continue
+		}
+		code, err := strconv.ParseInt(info.GetReason(), 10, 32)
+		if err != nil {
+			continue
+		}
+		return int32(code), true
+	}
+	return 0, false
+}
+
+// FromGRPCError is like FromStatus but accepts the error a gRPC call returns.
+func FromGRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return FromStatus(s)
+}
+
+// UnaryServerInterceptor translates any error returned by the handler into a
+// status carrying the canonical gRPC code for its merr class.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, ToStatus(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return ToStatus(err).Err()
+		}
+		return nil
+	}
+}
+
+// UnaryClientInterceptor reconstructs the original milvus error (code and
+// retriable bit included) from the status returned by the server.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromGRPCError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of UnaryClientInterceptor.
+// It converts only the error returned when opening the stream; errors that
+// later surface from the stream's RecvMsg or SendMsg are returned unconverted.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return stream, FromGRPCError(err)
+		}
+		return stream, nil
+	}
+}
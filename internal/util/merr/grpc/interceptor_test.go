@@ -0,0 +1,77 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+func TestToStatusFromStatusRoundTrip(t *testing.T) {
+	cases := []error{
+		merr.WrapErrCollectionNotFound("test_collection"),
+		merr.WrapErrServiceUnavailable("down for maintenance"),
+		merr.WrapErrServiceRequestLimitExceeded(100),
+	}
+
+	for _, err := range cases {
+		got := FromStatus(ToStatus(err))
+		if merr.Code(got) != merr.Code(err) {
+			t.Errorf("round trip code mismatch for %v: got %d, want %d", err, merr.Code(got), merr.Code(err))
+		}
+		if merr.IsRetriable(got) != merr.IsRetriable(err) {
+			t.Errorf("round trip retriable mismatch for %v", err)
+		}
+	}
+}
+
+func TestToStatusUsesRegistryCode(t *testing.T) {
+	if got := ToStatus(merr.ErrNodeLack).Code(); got != codes.FailedPrecondition {
+		t.Fatalf("expected ErrNodeLack to map to FailedPrecondition via merr.Describe, got %v", got)
+	}
+}
+
+func TestToStatusFromStatusNil(t *testing.T) {
+	if ToStatus(nil).Code() != codes.OK {
+		t.Fatal("expected OK for nil error")
+	}
+	if FromStatus(ToStatus(nil)) != nil {
+		t.Fatal("expected nil error for an OK status")
+	}
+}
+
+// TestFromStatusWithoutErrorInfo guards against a framework-generated status
+// (or one from a peer that hasn't rolled out this interceptor) being turned
+// into a nil "success" error just because it carries no ErrorInfo detail.
+func TestFromStatusWithoutErrorInfo(t *testing.T) {
+	s := status.New(codes.Unavailable, "connection refused")
+
+	err := FromStatus(s)
+	if err == nil {
+		t.Fatal("expected a non-nil error for a failing status with no ErrorInfo detail")
+	}
+	if merr.Code(err) == 0 {
+		t.Fatalf("expected a non-zero milvus code, got 0 (err=%v)", err)
+	}
+	if err.Error() == "" {
+		t.Fatal("expected the original status message to be preserved")
+	}
+}
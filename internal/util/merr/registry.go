@@ -0,0 +1,150 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run ./cmd/merrdoc
+
+package merr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Descriptor documents one merr error class: its stable numeric code, a
+// symbolic name, its default gRPC/HTTP status, its retriable flag, and a
+// short description.
+type Descriptor struct {
+	Code        int32
+	Name        string
+	GRPCCode    codes.Code
+	HTTPCode    int
+	Retriable   bool
+	Description string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[int32]Descriptor{}
+)
+
+// Register declares the canonical descriptor for a merr error code.
+func Register(d Descriptor) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[d.Code] = d
+}
+
+// Describe looks up the descriptor registered for code, if any.
+func Describe(code int32) (Descriptor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	d, ok := registry[code]
+	return d, ok
+}
+
+// HTTPStatus returns the HTTP status to report for err, falling back to 500
+// when its code has no registered descriptor.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	if d, ok := Describe(Code(err)); ok {
+		return d.HTTPCode
+	}
+	return http.StatusInternalServerError
+}
+
+// Descriptors returns every registered descriptor sorted by code, for
+// documentation generation (see cmd/merrdoc).
+func Descriptors() []Descriptor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Descriptor, 0, len(registry))
+	for _, d := range registry {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// MissingDescriptors reports which of the given codes have no registered descriptor.
+func MissingDescriptors(errCodes ...int32) []int32 {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var missing []int32
+	for _, code := range errCodes {
+		if _, ok := registry[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	return missing
+}
+
+// DumpJSON renders the registry as indented JSON.
+func DumpJSON() ([]byte, error) {
+	return json.MarshalIndent(Descriptors(), "", "  ")
+}
+
+// DumpMarkdown renders the registry as a Markdown table for cmd/merrdoc.
+func DumpMarkdown() string {
+	var b strings.Builder
+	b.WriteString("| Code | Name | gRPC Code | HTTP Status | Retriable | Description |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, d := range Descriptors() {
+		fmt.Fprintf(&b, "| %d | %s | %s | %d | %t | %s |\n",
+			d.Code, d.Name, d.GRPCCode, d.HTTPCode, d.Retriable, d.Description)
+	}
+	return b.String()
+}
+
+func init() {
+	Register(Descriptor{Code: Code(ErrServiceNotReady), Name: "ErrServiceNotReady", GRPCCode: codes.Unavailable, HTTPCode: http.StatusServiceUnavailable, Retriable: IsRetriable(ErrServiceNotReady), Description: "service is starting up and not yet ready to serve requests"})
+	Register(Descriptor{Code: Code(ErrServiceUnavailable), Name: "ErrServiceUnavailable", GRPCCode: codes.Unavailable, HTTPCode: http.StatusServiceUnavailable, Retriable: IsRetriable(ErrServiceUnavailable), Description: "service is temporarily unavailable"})
+	Register(Descriptor{Code: Code(ErrServiceMemoryLimitExceeded), Name: "ErrServiceMemoryLimitExceeded", GRPCCode: codes.ResourceExhausted, HTTPCode: http.StatusInsufficientStorage, Retriable: IsRetriable(ErrServiceMemoryLimitExceeded), Description: "predicted memory usage exceeds the configured limit"})
+	Register(Descriptor{Code: Code(ErrServiceRequestLimitExceeded), Name: "ErrServiceRequestLimitExceeded", GRPCCode: codes.ResourceExhausted, HTTPCode: http.StatusTooManyRequests, Retriable: IsRetriable(ErrServiceRequestLimitExceeded), Description: "the number of concurrent requests exceeds the configured limit"})
+
+	Register(Descriptor{Code: Code(ErrCollectionNotFound), Name: "ErrCollectionNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrCollectionNotFound), Description: "collection does not exist"})
+	Register(Descriptor{Code: Code(ErrCollectionNotLoaded), Name: "ErrCollectionNotLoaded", GRPCCode: codes.FailedPrecondition, HTTPCode: http.StatusPreconditionFailed, Retriable: IsRetriable(ErrCollectionNotLoaded), Description: "collection exists but is not loaded"})
+
+	Register(Descriptor{Code: Code(ErrPartitionNotFound), Name: "ErrPartitionNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrPartitionNotFound), Description: "partition does not exist"})
+	Register(Descriptor{Code: Code(ErrPartitionNotLoaded), Name: "ErrPartitionNotLoaded", GRPCCode: codes.FailedPrecondition, HTTPCode: http.StatusPreconditionFailed, Retriable: IsRetriable(ErrPartitionNotLoaded), Description: "partition exists but is not loaded"})
+
+	Register(Descriptor{Code: Code(ErrResourceGroupNotFound), Name: "ErrResourceGroupNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrResourceGroupNotFound), Description: "resource group does not exist"})
+	Register(Descriptor{Code: Code(ErrReplicaNotFound), Name: "ErrReplicaNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrReplicaNotFound), Description: "replica does not exist"})
+	Register(Descriptor{Code: Code(ErrChannelNotFound), Name: "ErrChannelNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrChannelNotFound), Description: "channel does not exist"})
+
+	Register(Descriptor{Code: Code(ErrSegmentNotFound), Name: "ErrSegmentNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrSegmentNotFound), Description: "segment does not exist"})
+	Register(Descriptor{Code: Code(ErrSegmentNotLoaded), Name: "ErrSegmentNotLoaded", GRPCCode: codes.FailedPrecondition, HTTPCode: http.StatusPreconditionFailed, Retriable: IsRetriable(ErrSegmentNotLoaded), Description: "segment exists but is not loaded"})
+	Register(Descriptor{Code: Code(ErrSegmentLack), Name: "ErrSegmentLack", GRPCCode: codes.FailedPrecondition, HTTPCode: http.StatusPreconditionFailed, Retriable: IsRetriable(ErrSegmentLack), Description: "fewer segments are loaded than the query requires"})
+
+	Register(Descriptor{Code: Code(ErrIndexNotFound), Name: "ErrIndexNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrIndexNotFound), Description: "index does not exist"})
+
+	Register(Descriptor{Code: Code(ErrNodeNotFound), Name: "ErrNodeNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrNodeNotFound), Description: "node does not exist"})
+	Register(Descriptor{Code: Code(ErrNodeOffline), Name: "ErrNodeOffline", GRPCCode: codes.Unavailable, HTTPCode: http.StatusServiceUnavailable, Retriable: IsRetriable(ErrNodeOffline), Description: "node is offline"})
+	Register(Descriptor{Code: Code(ErrNodeLack), Name: "ErrNodeLack", GRPCCode: codes.FailedPrecondition, HTTPCode: http.StatusPreconditionFailed, Retriable: IsRetriable(ErrNodeLack), Description: "fewer nodes are available than required"})
+
+	Register(Descriptor{Code: Code(ErrIoKeyNotFound), Name: "ErrIoKeyNotFound", GRPCCode: codes.NotFound, HTTPCode: http.StatusNotFound, Retriable: IsRetriable(ErrIoKeyNotFound), Description: "object storage key does not exist"})
+	Register(Descriptor{Code: Code(ErrIoFailed), Name: "ErrIoFailed", GRPCCode: codes.Internal, HTTPCode: http.StatusInternalServerError, Retriable: IsRetriable(ErrIoFailed), Description: "object storage operation failed"})
+
+	Register(Descriptor{Code: Code(ErrParameterInvalid), Name: "ErrParameterInvalid", GRPCCode: codes.InvalidArgument, HTTPCode: http.StatusBadRequest, Retriable: IsRetriable(ErrParameterInvalid), Description: "request parameter is invalid"})
+}
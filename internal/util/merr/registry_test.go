@@ -0,0 +1,148 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merr
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+// sentinelByName resolves the symbolic name of every Err* sentinel used by a
+// WrapErrXxx helper to its value, so TestRegistryComplete can turn the names
+// it finds in utils.go into codes. This is still a hand-maintained map, but
+// unlike a flat list of sentinels it's cross-checked against utils.go itself
+// below: a sentinel newly wired into a WrapErrXxx helper without a matching
+// entry here fails the test instead of passing silently.
+var sentinelByName = map[string]error{
+	"ErrServiceNotReady":             ErrServiceNotReady,
+	"ErrServiceUnavailable":          ErrServiceUnavailable,
+	"ErrServiceMemoryLimitExceeded":  ErrServiceMemoryLimitExceeded,
+	"ErrServiceRequestLimitExceeded": ErrServiceRequestLimitExceeded,
+	"ErrCollectionNotFound":          ErrCollectionNotFound,
+	"ErrCollectionNotLoaded":         ErrCollectionNotLoaded,
+	"ErrPartitionNotFound":           ErrPartitionNotFound,
+	"ErrPartitionNotLoaded":          ErrPartitionNotLoaded,
+	"ErrResourceGroupNotFound":       ErrResourceGroupNotFound,
+	"ErrReplicaNotFound":             ErrReplicaNotFound,
+	"ErrChannelNotFound":             ErrChannelNotFound,
+	"ErrSegmentNotFound":             ErrSegmentNotFound,
+	"ErrSegmentNotLoaded":            ErrSegmentNotLoaded,
+	"ErrSegmentLack":                 ErrSegmentLack,
+	"ErrIndexNotFound":               ErrIndexNotFound,
+	"ErrNodeNotFound":                ErrNodeNotFound,
+	"ErrNodeOffline":                 ErrNodeOffline,
+	"ErrNodeLack":                    ErrNodeLack,
+	"ErrIoKeyNotFound":               ErrIoKeyNotFound,
+	"ErrIoFailed":                    ErrIoFailed,
+	"ErrParameterInvalid":            ErrParameterInvalid,
+}
+
+var errIdentPattern = regexp.MustCompile(`^Err[A-Z]\w*$`)
+
+// wrappedSentinelNames parses utils.go and returns every Err* identifier
+// referenced inside a WrapErrXxx function body. Deriving the set this way,
+// rather than from a second hand-maintained list, means a sentinel that's
+// wired into a new Wrap helper but never added to sentinelByName (or
+// registered in registry.go) is actually caught below instead of being
+// silently skipped. It's still best-effort: a sentinel that exists but is
+// never referenced by any WrapErrXxx helper won't be found by this scan.
+func wrappedSentinelNames(t *testing.T) map[string]bool {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "utils.go", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse utils.go: %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "WrapErr") {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && errIdentPattern.MatchString(id.Name) {
+				names[id.Name] = true
+			}
+			return true
+		})
+	}
+	return names
+}
+
+func TestRegistryComplete(t *testing.T) {
+	names := wrappedSentinelNames(t)
+	if len(names) == 0 {
+		t.Fatal("expected to find at least one Err* sentinel referenced by a WrapErrXxx helper in utils.go")
+	}
+
+	var mCodes []int32
+	for name := range names {
+		sentinel, ok := sentinelByName[name]
+		if !ok {
+			t.Errorf("utils.go references sentinel %s, but it has no entry in sentinelByName", name)
+			continue
+		}
+		mCodes = append(mCodes, Code(sentinel))
+	}
+
+	if missing := MissingDescriptors(mCodes...); len(missing) > 0 {
+		t.Fatalf("sentinels missing a registry.Register entry: %v", missing)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	d, ok := Describe(Code(ErrCollectionNotFound))
+	if !ok {
+		t.Fatal("expected ErrCollectionNotFound to be registered")
+	}
+	if d.Name != "ErrCollectionNotFound" || d.GRPCCode != codes.NotFound || d.HTTPCode != http.StatusNotFound {
+		t.Fatalf("unexpected descriptor: %+v", d)
+	}
+
+	if _, ok := Describe(-1); ok {
+		t.Fatal("expected an unregistered code to be absent")
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{ErrCollectionNotFound, http.StatusNotFound},
+		{ErrServiceRequestLimitExceeded, http.StatusTooManyRequests},
+		{ErrServiceUnavailable, http.StatusServiceUnavailable},
+		{ErrServiceMemoryLimitExceeded, http.StatusInsufficientStorage},
+		{ErrParameterInvalid, http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		if got := HTTPStatus(c.err); got != c.want {
+			t.Errorf("HTTPStatus(%v) = %d, want %d", c.err, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+// CircuitBreaker trips after Threshold consecutive unavailable results and
+// half-opens after Cooldown.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu          sync.Mutex
+	consecutive int
+	openUntil   time.Time
+	halfOpen    bool
+}
+
+// NewCircuitBreaker builds a breaker that trips after threshold consecutive
+// unavailable results and cools down for the given duration.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// Allow reports whether a new attempt may proceed, transitioning an open
+// breaker to half-open once the cooldown has elapsed.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if time.Now().Before(b.openUntil) {
+		return false
+	}
+
+	// Cooldown elapsed: let exactly one probe through.
+	if !b.halfOpen {
+		b.halfOpen = true
+		return true
+	}
+	return false
+}
+
+// OnResult updates the consecutive-failure count for an attempt's error,
+// tripping the breaker once Threshold is reached. A failed half-open probe
+// always re-trips with a fresh cooldown, whatever the error, so the breaker
+// can't get stuck open forever on a probe that failed for some other reason.
+func (b *CircuitBreaker) OnResult(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !isUnavailable(err) {
+		b.consecutive = 0
+		if b.halfOpen {
+			b.trip()
+		}
+		return
+	}
+
+	b.consecutive++
+	if b.halfOpen || b.consecutive >= b.Threshold {
+		b.trip()
+	}
+}
+
+// OnSuccess resets the breaker to fully closed.
+func (b *CircuitBreaker) OnSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutive = 0
+	b.openUntil = time.Time{}
+	b.halfOpen = false
+}
+
+func (b *CircuitBreaker) trip() {
+	b.openUntil = time.Now().Add(b.Cooldown)
+	b.halfOpen = false
+}
+
+func isUnavailable(err error) bool {
+	return errors.Is(err, merr.ErrServiceUnavailable) || errors.Is(err, merr.ErrServiceNotReady)
+}
@@ -0,0 +1,113 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+func TestCircuitBreakerTripsOnConsecutiveUnavailable(t *testing.T) {
+	b := NewCircuitBreaker(3, 50*time.Millisecond)
+
+	for i := 0; i < 2; i++ {
+		if !b.Allow() {
+			t.Fatalf("breaker should still be closed after %d failures", i)
+		}
+		b.OnResult(merr.ErrServiceUnavailable)
+	}
+	b.OnResult(merr.ErrServiceUnavailable)
+
+	if b.Allow() {
+		t.Fatal("breaker should be open after threshold consecutive failures")
+	}
+}
+
+func TestCircuitBreakerNonConsecutiveFailureResets(t *testing.T) {
+	b := NewCircuitBreaker(2, 50*time.Millisecond)
+
+	b.OnResult(merr.ErrServiceUnavailable)
+	b.OnResult(merr.ErrParameterInvalid) // not unavailable: should break the streak
+	b.OnResult(merr.ErrServiceUnavailable)
+
+	if !b.Allow() {
+		t.Fatal("breaker should still be closed: the unavailable streak was interrupted")
+	}
+}
+
+func TestCircuitBreakerHalfOpenAfterCooldown(t *testing.T) {
+	b := NewCircuitBreaker(1, 20*time.Millisecond)
+
+	b.OnResult(merr.ErrServiceUnavailable)
+	if b.Allow() {
+		t.Fatal("breaker should be open immediately after tripping")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("breaker should half-open and allow a single probe after cooldown")
+	}
+	if b.Allow() {
+		t.Fatal("breaker should not allow a second concurrent probe while half-open")
+	}
+}
+
+func TestCircuitBreakerReopensAfterFailedProbe(t *testing.T) {
+	b := NewCircuitBreaker(1, 20*time.Millisecond)
+
+	b.OnResult(merr.ErrServiceUnavailable)
+	time.Sleep(30 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("breaker should half-open and allow a probe after cooldown")
+	}
+	// The probe fails for a reason other than unavailability (e.g. a
+	// per-attempt timeout). The breaker must re-trip rather than getting
+	// stuck half-open forever.
+	b.OnResult(context.DeadlineExceeded)
+
+	if b.Allow() {
+		t.Fatal("breaker should be open again right after a failed half-open probe")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("breaker should half-open and allow another probe after the fresh cooldown")
+	}
+	b.OnSuccess()
+
+	if !b.Allow() {
+		t.Fatal("breaker should be fully closed after a successful probe")
+	}
+}
+
+func TestCircuitBreakerResetsOnSuccess(t *testing.T) {
+	b := NewCircuitBreaker(2, 20*time.Millisecond)
+
+	b.OnResult(merr.ErrServiceUnavailable)
+	b.OnSuccess()
+	b.OnResult(merr.ErrServiceUnavailable)
+
+	if !b.Allow() {
+		t.Fatal("breaker should still be closed: OnSuccess should have reset the streak")
+	}
+}
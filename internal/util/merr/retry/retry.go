@@ -0,0 +1,178 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry drives retries off merr.IsRetriable.
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+// OnRetryFunc is invoked after each failed attempt, before the next one runs.
+type OnRetryFunc func(attempt int, err error, delay time.Duration)
+
+// Options configures Do.
+type Options struct {
+	maxAttempts       int
+	initialBackoff    time.Duration
+	maxBackoff        time.Duration
+	multiplier        float64
+	perAttemptTimeout time.Duration
+	onRetry           OnRetryFunc
+	breaker           *CircuitBreaker
+}
+
+// Option mutates Options.
+type Option func(*Options)
+
+// WithMaxAttempts caps the number of attempts, including the first one.
+func WithMaxAttempts(n int) Option {
+	return func(o *Options) { o.maxAttempts = n }
+}
+
+// WithInitialBackoff sets the backoff before the first retry; the actual
+// delay is drawn uniformly from [0, d).
+func WithInitialBackoff(d time.Duration) Option {
+	return func(o *Options) { o.initialBackoff = d }
+}
+
+// WithMaxBackoff caps the delay between retries.
+func WithMaxBackoff(d time.Duration) Option {
+	return func(o *Options) { o.maxBackoff = d }
+}
+
+// WithMultiplier sets the exponential backoff growth factor.
+func WithMultiplier(m float64) Option {
+	return func(o *Options) { o.multiplier = m }
+}
+
+// WithPerAttemptTimeout bounds how long Do waits for a single attempt of fn.
+// fn is not interrupted: after the timeout it keeps running in the background.
+func WithPerAttemptTimeout(d time.Duration) Option {
+	return func(o *Options) { o.perAttemptTimeout = d }
+}
+
+// WithOnRetry registers a hook invoked after each failed attempt that will be
+// retried.
+func WithOnRetry(f OnRetryFunc) Option {
+	return func(o *Options) { o.onRetry = f }
+}
+
+// WithCircuitBreaker fails fast while the breaker is open.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(o *Options) { o.breaker = breaker }
+}
+
+func defaultOptions() *Options {
+	return &Options{
+		maxAttempts:    10,
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     10 * time.Second,
+		multiplier:     2.0,
+	}
+}
+
+// Do calls fn, retrying with exponential backoff and full jitter while fn
+// returns a retriable error.
+func Do(ctx context.Context, fn func() error, opts ...Option) error {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	backoff := o.initialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= o.maxAttempts; attempt++ {
+		if o.breaker != nil && !o.breaker.Allow() {
+			return merr.WrapErrServiceUnavailable("circuit breaker open")
+		}
+
+		lastErr = callWithTimeout(ctx, fn, o.perAttemptTimeout)
+
+		if lastErr == nil {
+			if o.breaker != nil {
+				o.breaker.OnSuccess()
+			}
+			return nil
+		}
+
+		if o.breaker != nil {
+			o.breaker.OnResult(lastErr)
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if !merr.IsRetriable(lastErr) {
+			return lastErr
+		}
+
+		if attempt == o.maxAttempts {
+			break
+		}
+
+		delay := jitter(backoff)
+		if o.onRetry != nil {
+			o.onRetry(attempt, lastErr, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		backoff = time.Duration(float64(backoff) * o.multiplier)
+		if backoff > o.maxBackoff {
+			backoff = o.maxBackoff
+		}
+	}
+
+	return lastErr
+}
+
+func callWithTimeout(ctx context.Context, fn func() error, timeout time.Duration) error {
+	if timeout <= 0 {
+		return fn()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// jitter applies full jitter: a uniform random delay in [0, d).
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
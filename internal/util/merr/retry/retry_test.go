@@ -0,0 +1,109 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/util/merr"
+)
+
+func TestDoSucceedsWithoutRetry(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), func() error {
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected fn to be called once, got %d", calls)
+	}
+}
+
+func TestDoAbortsImmediatelyOnNonRetriableError(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), func() error {
+		calls++
+		return merr.ErrParameterInvalid
+	}, WithMaxAttempts(5), WithInitialBackoff(time.Millisecond))
+
+	if calls != 1 {
+		t.Fatalf("expected fn to be called once for a non-retriable error, got %d", calls)
+	}
+	if merr.Code(err) != merr.Code(merr.ErrParameterInvalid) {
+		t.Fatalf("expected the non-retriable error to be returned unchanged, got %v", err)
+	}
+}
+
+func TestDoExhaustsMaxAttempts(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), func() error {
+		calls++
+		return merr.ErrServiceUnavailable
+	}, WithMaxAttempts(3), WithInitialBackoff(time.Millisecond), WithMaxBackoff(time.Millisecond))
+
+	if calls != 3 {
+		t.Fatalf("expected fn to be called maxAttempts times, got %d", calls)
+	}
+	if merr.Code(err) != merr.Code(merr.ErrServiceUnavailable) {
+		t.Fatalf("expected the last attempt's error to be returned, got %v", err)
+	}
+}
+
+func TestDoStopsOnContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := Do(ctx, func() error {
+		calls++
+		if calls == 1 {
+			cancel()
+		}
+		return merr.ErrServiceUnavailable
+	}, WithMaxAttempts(10), WithInitialBackoff(time.Millisecond))
+
+	if calls != 1 {
+		t.Fatalf("expected fn to stop being called once the context is canceled, got %d calls", calls)
+	}
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDoInvokesOnRetryForEachFailedAttempt(t *testing.T) {
+	var retries []int
+	calls := 0
+	err := Do(context.Background(), func() error {
+		calls++
+		if calls == 3 {
+			return nil
+		}
+		return merr.ErrServiceUnavailable
+	}, WithMaxAttempts(5), WithInitialBackoff(time.Millisecond), WithOnRetry(func(attempt int, err error, delay time.Duration) {
+		retries = append(retries, attempt)
+	}))
+
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if len(retries) != 2 {
+		t.Fatalf("expected OnRetry to fire for each of the 2 failed attempts, got %v", retries)
+	}
+}
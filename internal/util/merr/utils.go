@@ -57,7 +57,10 @@ func IsRetriable(err error) bool {
 }
 
 // Status returns a status according to the given err,
-// returns Success status if err is nil
+// returns Success status if err is nil.
+// Structured fields attached via WithField aren't carried by commonpb.Status
+// (it has no details slot); they're available through Fields/ZapFields and,
+// over gRPC transport, as a google.rpc.ErrorInfo detail (see merr/grpc.ToStatus).
 func Status(err error) *commonpb.Status {
 	if err == nil {
 		return successStatus
@@ -89,7 +92,7 @@ func Error(status *commonpb.Status) error {
 
 // Service related
 func WrapErrServiceNotReady(stage string, msg ...string) error {
-	err := errors.Wrapf(ErrServiceNotReady, "stage=%s", stage)
+	err := WithField(ErrServiceNotReady, "stage", stage)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -105,7 +108,10 @@ func WrapErrServiceUnavailable(reason string, msg ...string) error {
 }
 
 func WrapErrServiceMemoryLimitExceeded(predict, limit float32, msg ...string) error {
-	err := errors.Wrapf(ErrServiceMemoryLimitExceeded, "predict=%v, limit=%v", predict, limit)
+	err := withFields(ErrServiceMemoryLimitExceeded,
+		fieldPair{key: "predict", value: predict},
+		fieldPair{key: "limit", value: limit},
+	)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -113,7 +119,7 @@ func WrapErrServiceMemoryLimitExceeded(predict, limit float32, msg ...string) er
 }
 
 func WrapErrServiceRequestLimitExceeded(limit int32, msg ...string) error {
-	err := errors.Wrapf(ErrServiceRequestLimitExceeded, "limit=%v", limit)
+	err := WithField(ErrServiceRequestLimitExceeded, "limit", limit)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -122,7 +128,7 @@ func WrapErrServiceRequestLimitExceeded(limit int32, msg ...string) error {
 
 // Collection related
 func WrapErrCollectionNotFound(collection any, msg ...string) error {
-	err := wrapWithField(ErrCollectionNotFound, "collection", collection)
+	err := WithField(ErrCollectionNotFound, "collection", collection)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -130,7 +136,7 @@ func WrapErrCollectionNotFound(collection any, msg ...string) error {
 }
 
 func WrapErrCollectionNotLoaded(collection any, msg ...string) error {
-	err := wrapWithField(ErrCollectionNotLoaded, "collection", collection)
+	err := WithField(ErrCollectionNotLoaded, "collection", collection)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -139,7 +145,7 @@ func WrapErrCollectionNotLoaded(collection any, msg ...string) error {
 
 // Partition related
 func WrapErrPartitionNotFound(partition any, msg ...string) error {
-	err := wrapWithField(ErrPartitionNotFound, "partition", partition)
+	err := WithField(ErrPartitionNotFound, "partition", partition)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -147,7 +153,7 @@ func WrapErrPartitionNotFound(partition any, msg ...string) error {
 }
 
 func WrapErrPartitionNotLoaded(partition any, msg ...string) error {
-	err := wrapWithField(ErrPartitionNotLoaded, "partition", partition)
+	err := WithField(ErrPartitionNotLoaded, "partition", partition)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -156,7 +162,7 @@ func WrapErrPartitionNotLoaded(partition any, msg ...string) error {
 
 // ResourceGroup related
 func WrapErrResourceGroupNotFound(rg any, msg ...string) error {
-	err := wrapWithField(ErrResourceGroupNotFound, "rg", rg)
+	err := WithField(ErrResourceGroupNotFound, "rg", rg)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -165,7 +171,7 @@ func WrapErrResourceGroupNotFound(rg any, msg ...string) error {
 
 // Replica related
 func WrapErrReplicaNotFound(id int64, msg ...string) error {
-	err := wrapWithField(ErrReplicaNotFound, "replica", id)
+	err := WithField(ErrReplicaNotFound, "replica", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -174,7 +180,7 @@ func WrapErrReplicaNotFound(id int64, msg ...string) error {
 
 // Channel related
 func WrapErrChannelNotFound(name string, msg ...string) error {
-	err := wrapWithField(ErrChannelNotFound, "channel", name)
+	err := WithField(ErrChannelNotFound, "channel", name)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -183,7 +189,7 @@ func WrapErrChannelNotFound(name string, msg ...string) error {
 
 // Segment related
 func WrapErrSegmentNotFound(id int64, msg ...string) error {
-	err := wrapWithField(ErrSegmentNotFound, "segment", id)
+	err := WithField(ErrSegmentNotFound, "segment", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -191,7 +197,7 @@ func WrapErrSegmentNotFound(id int64, msg ...string) error {
 }
 
 func WrapErrSegmentNotLoaded(id int64, msg ...string) error {
-	err := wrapWithField(ErrSegmentNotLoaded, "segment", id)
+	err := WithField(ErrSegmentNotLoaded, "segment", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -199,7 +205,7 @@ func WrapErrSegmentNotLoaded(id int64, msg ...string) error {
 }
 
 func WrapErrSegmentLack(id int64, msg ...string) error {
-	err := wrapWithField(ErrSegmentLack, "segment", id)
+	err := WithField(ErrSegmentLack, "segment", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -217,7 +223,7 @@ func WrapErrIndexNotFound(msg ...string) error {
 
 // Node related
 func WrapErrNodeNotFound(id int64, msg ...string) error {
-	err := wrapWithField(ErrNodeNotFound, "node", id)
+	err := WithField(ErrNodeNotFound, "node", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -225,7 +231,7 @@ func WrapErrNodeNotFound(id int64, msg ...string) error {
 }
 
 func WrapErrNodeOffline(id int64, msg ...string) error {
-	err := wrapWithField(ErrNodeOffline, "node", id)
+	err := WithField(ErrNodeOffline, "node", id)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -233,7 +239,10 @@ func WrapErrNodeOffline(id int64, msg ...string) error {
 }
 
 func WrapErrNodeLack(expectedNum, actualNum int64, msg ...string) error {
-	err := errors.Wrapf(ErrNodeLack, "expectedNum=%d, actualNum=%d", expectedNum, actualNum)
+	err := withFields(ErrNodeLack,
+		fieldPair{key: "expectedNum", value: expectedNum},
+		fieldPair{key: "actualNum", value: actualNum},
+	)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -242,7 +251,7 @@ func WrapErrNodeLack(expectedNum, actualNum int64, msg ...string) error {
 
 // IO related
 func WrapErrIoKeyNotFound(key string, msg ...string) error {
-	err := errors.Wrapf(ErrIoKeyNotFound, "key=%s", key)
+	err := WithField(ErrIoKeyNotFound, "key", key)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -250,7 +259,7 @@ func WrapErrIoKeyNotFound(key string, msg ...string) error {
 }
 
 func WrapErrIoFailed(key string, msg ...string) error {
-	err := errors.Wrapf(ErrIoFailed, "key=%s", key)
+	err := WithField(ErrIoFailed, "key", key)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -259,7 +268,10 @@ func WrapErrIoFailed(key string, msg ...string) error {
 
 // Parameter related
 func WrapErrParameterInvalid[T any](expected, actual T, msg ...string) error {
-	err := errors.Wrapf(ErrParameterInvalid, "expected=%v, actual=%v", expected, actual)
+	err := withFields(ErrParameterInvalid,
+		fieldPair{key: "expected", value: expected},
+		fieldPair{key: "actual", value: actual},
+	)
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
@@ -267,13 +279,10 @@ func WrapErrParameterInvalid[T any](expected, actual T, msg ...string) error {
 }
 
 func WrapErrParameterInvalidRange[T any](lower, upper, actual T, msg ...string) error {
-	err := errors.Wrapf(ErrParameterInvalid, "expected in (%v, %v), actual=%v", lower, upper, actual)
+	wrapped := errors.Wrapf(ErrParameterInvalid, "expected in (%v, %v), actual=%v", lower, upper, actual)
+	err := attachFields(wrapped, map[string]any{"lower": lower, "upper": upper, "actual": actual})
 	if len(msg) > 0 {
 		err = errors.Wrap(err, strings.Join(msg, "; "))
 	}
 	return err
 }
-
-func wrapWithField(err error, name string, value any) error {
-	return errors.Wrapf(err, "%s=%v", name, value)
-}